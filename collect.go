@@ -1,8 +1,6 @@
 package promtest
 
 import (
-	"strings"
-
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 )
@@ -29,28 +27,7 @@ type Reporter interface {
 //   promtest.AssertEquals(t, 5, requestMethodMetric, "method=GET")
 func AssertEquals(t Reporter, expected float64, metric prometheus.Collector, labels ...string) {
 	t.Helper()
-	allLabels := CollectMetrics(t, metric)
-
-	var filteredLabels []*dto.Metric
-	for _, m := range allLabels {
-		if matches(t, m, labels) {
-			filteredLabels = append(filteredLabels, m)
-		}
-	}
-
-	var actualValue float64
-	for _, m := range filteredLabels {
-		switch {
-		case m.Counter != nil:
-			a := m.GetCounter().GetValue()
-			actualValue += a
-		case m.Gauge != nil:
-			a := m.GetGauge().GetValue()
-			actualValue += a
-		default:
-			t.Fatal("neither a counter nor a gauge")
-		}
-	}
+	actualValue := aggregateValue(t, filterMetrics(t, metric, labels))
 
 	if expected != actualValue {
 		t.Errorf("Expected metric with labels=%q to have a value of %v but we got %v", labels, expected, actualValue)
@@ -66,17 +43,10 @@ func AssertEquals(t Reporter, expected float64, metric prometheus.Collector, lab
 //   promtest.AssertSummarySampleCount(t, 5, requestDurationsMetric, "method=GET")
 func AssertSummarySampleCount(t Reporter, expected int, metric prometheus.Collector, labels ...string) {
 	t.Helper()
-	allLabels := CollectMetrics(t, metric)
-
-	var filteredLabels []*dto.Metric
-	for _, m := range allLabels {
-		if matches(t, m, labels) {
-			filteredLabels = append(filteredLabels, m)
-		}
-	}
+	filtered := filterMetrics(t, metric, labels)
 
 	var actualValue int
-	for _, m := range filteredLabels {
+	for _, m := range filtered {
 		if m.Summary == nil {
 			t.Fatal("metric is not a summary")
 		}
@@ -108,32 +78,21 @@ func GetMetric(t Reporter, metric prometheus.Collector, expectedLabels ...string
 	return nil
 }
 
+// matches reports whether m satisfies all of the given "key=value" style
+// label expressions. It supports the full promtest label matcher DSL: plain
+// equality (key=value), negation (key!=value) and regular expressions
+// (key=~regex, key!~regex). See promtest.Matcher for the programmatic
+// equivalent.
 func matches(t Reporter, m *dto.Metric, expectedLabels []string) bool {
-	for _, expected := range expectedLabels {
-		parts := strings.SplitN(expected, "=", 2)
-		if len(parts) != 2 {
-			t.Error("metrics labels should have two parts, e.g. key=value")
-			return false
-		}
-
-		expectedName, expectedValue := parts[0], parts[1]
-		found := false
-
-		for _, l := range m.Label {
-			if l.Name == nil || l.Value == nil {
-				continue
-			}
-
-			if *l.Name == expectedName && *l.Value == expectedValue {
-				found = true
-				break
-			}
-		}
+	t.Helper()
+	matchers := parseMatchers(t, expectedLabels)
+	if len(matchers) != len(expectedLabels) {
+		return false
+	}
 
-		if !found {
-			t.Logf("label %q not found in %q", expected, m.Label)
-			return false
-		}
+	if !matchesAll(m, matchers) {
+		t.Logf("metric with labels %q does not match %q", m.Label, expectedLabels)
+		return false
 	}
 
 	return true
@@ -0,0 +1,230 @@
+package promtest
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// CollectAndCompare registers the given collector with a fresh
+// prometheus.NewPedanticRegistry(), gathers its metrics and compares the text
+// exposition of the metric families named in metricNames (or all families if
+// none are given) against the expected exposition format read from expected.
+// If the two differ, a diff is reported via t.Errorf.
+//
+// Example usage:
+//   promtest.CollectAndCompare(t, requestsCollector, strings.NewReader(`
+//   # HELP requests_total Total number of requests.
+//   # TYPE requests_total counter
+//   requests_total{method="GET"} 5
+//   `))
+func CollectAndCompare(t Reporter, c prometheus.Collector, expected io.Reader, metricNames ...string) {
+	t.Helper()
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		t.Fatalf("Failed to register collector: %v", err)
+	}
+
+	GatherAndCompare(t, reg, expected, metricNames...)
+}
+
+// GatherAndCompare gathers the metrics of the given gatherer and compares the
+// text exposition of the metric families named in metricNames (or all
+// families if none are given) against the expected exposition format read
+// from expected. If the two differ, a diff is reported via t.Errorf.
+func GatherAndCompare(t Reporter, g prometheus.Gatherer, expected io.Reader, metricNames ...string) {
+	t.Helper()
+
+	got, err := g.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	want := parseExpectedMetricFamilies(t, expected)
+
+	got = filterMetricFamiliesByName(got, metricNames)
+	want = filterMetricFamiliesByName(want, metricNames)
+
+	compareMetricFamilies(t, "Gathered metrics", got, want)
+}
+
+// parseExpectedMetricFamilies parses the text exposition format read from r
+// into metric families, normalized to the same shape prometheus.Gatherer
+// produces so they can be compared directly.
+func parseExpectedMetricFamilies(t Reporter, r io.Reader) []*dto.MetricFamily {
+	t.Helper()
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		t.Fatalf("Failed to parse expected metrics: %v", err)
+	}
+
+	return metricFamilySlice(families)
+}
+
+// fillMissingHelp fills in the Help of every family in want that has none,
+// using the Help of the same-named family in got. expfmt.TextParser leaves
+// Help nil when the input has no "# HELP" line for a family, which is a
+// natural way to write a terse golden fixture, but a real
+// prometheus.Gatherer always populates Help (if only with an empty string).
+// Without this, such a fixture could never compare equal to the real thing.
+func fillMissingHelp(got, want []*dto.MetricFamily) {
+	gotByName := make(map[string]*dto.MetricFamily, len(got))
+	for _, mf := range got {
+		gotByName[mf.GetName()] = mf
+	}
+
+	for _, mf := range want {
+		if mf.Help != nil {
+			continue
+		}
+
+		help := gotByName[mf.GetName()].GetHelp()
+		mf.Help = &help
+	}
+}
+
+// compareMetricFamilies compares two sets of metric families by their text
+// exposition, since a direct comparison of the raw *dto.MetricFamily structs
+// is unreliable: client_golang populates fields on gathered metrics — most
+// notably the CreatedTimestamp it auto-adds to counters, histograms and
+// summaries — that never round-trip through the text exposition format and
+// so can never be present on metric families parsed from an expected golden
+// fixture. context is prepended to the failure message to identify which
+// comparison failed.
+func compareMetricFamilies(t Reporter, context string, got, want []*dto.MetricFamily) {
+	t.Helper()
+
+	fillMissingHelp(got, want)
+	sortMetricFamilies(got)
+	sortMetricFamilies(want)
+
+	gotText, err := encodeMetricFamilies(got)
+	if err != nil {
+		t.Fatalf("Failed to encode gathered metrics: %v", err)
+	}
+
+	wantText, err := encodeMetricFamilies(want)
+	if err != nil {
+		t.Fatalf("Failed to encode expected metrics: %v", err)
+	}
+
+	if gotText == wantText {
+		return
+	}
+
+	t.Errorf("%s do not match the expected metrics:\n%s", context, diffLines(wantText, gotText))
+}
+
+// CollectAndFormat registers the given collector with a fresh
+// prometheus.NewPedanticRegistry(), gathers its metrics and renders the
+// metric families named in metricNames (or all families if none are given) in
+// the given exposition format. This is useful to create golden files for use
+// with promtest.CollectAndCompare(…).
+func CollectAndFormat(c prometheus.Collector, format expfmt.Format, metricNames ...string) ([]byte, error) {
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(c); err != nil {
+		return nil, err
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	families = filterMetricFamiliesByName(families, metricNames)
+	sortMetricFamilies(families)
+
+	var buf bytes.Buffer
+	enc := expfmt.NewEncoder(&buf, format)
+	for _, mf := range families {
+		if err := enc.Encode(mf); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// filterMetricFamiliesByName returns only the metric families whose name is
+// contained in names. If names is empty, families is returned unmodified.
+func filterMetricFamiliesByName(families []*dto.MetricFamily, names []string) []*dto.MetricFamily {
+	if len(names) == 0 {
+		return families
+	}
+
+	want := make(map[string]bool, len(names))
+	for _, n := range names {
+		want[n] = true
+	}
+
+	var filtered []*dto.MetricFamily
+	for _, mf := range families {
+		if want[mf.GetName()] {
+			filtered = append(filtered, mf)
+		}
+	}
+
+	return filtered
+}
+
+// metricFamilySlice turns the map returned by expfmt.TextParser into a slice
+// of metric families, the same shape as the result of gathering.
+func metricFamilySlice(families map[string]*dto.MetricFamily) []*dto.MetricFamily {
+	result := make([]*dto.MetricFamily, 0, len(families))
+	for _, mf := range families {
+		result = append(result, mf)
+	}
+
+	return result
+}
+
+// sortMetricFamilies canonicalizes the order of the given metric families by
+// name and the order of the metrics within each family by their label set, so
+// that two semantically equal sets of families compare and encode equally
+// regardless of the order they were gathered or parsed in.
+func sortMetricFamilies(families []*dto.MetricFamily) {
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+
+	for _, mf := range families {
+		metrics := mf.GetMetric()
+		sort.Slice(metrics, func(i, j int) bool {
+			return labelSetString(metrics[i]) < labelSetString(metrics[j])
+		})
+	}
+}
+
+// labelSetString renders the label set of a metric as a sorted, comparable
+// string.
+func labelSetString(m *dto.Metric) string {
+	labels := make([]string, 0, len(m.GetLabel()))
+	for _, l := range m.GetLabel() {
+		labels = append(labels, l.GetName()+"="+l.GetValue())
+	}
+	sort.Strings(labels)
+
+	return strings.Join(labels, ",")
+}
+
+// encodeMetricFamilies renders the given metric families using the text
+// exposition format.
+func encodeMetricFamilies(families []*dto.MetricFamily) (string, error) {
+	var buf bytes.Buffer
+	enc := expfmt.NewEncoder(&buf, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, mf := range families {
+		if err := enc.Encode(mf); err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
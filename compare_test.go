@@ -0,0 +1,72 @@
+package promtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+func TestCollectAndCompare(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	counter.Add(5)
+
+	CollectAndCompare(t, counter, strings.NewReader(`# HELP requests_total Total number of requests.
+# TYPE requests_total counter
+requests_total 5
+`))
+}
+
+// TestCollectAndCompareWithoutHelp makes sure a terse golden fixture that
+// omits the "# HELP" line still compares equal to a real collector, which
+// always has a non-nil Help.
+func TestCollectAndCompareWithoutHelp(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	counter.Add(5)
+
+	CollectAndCompare(t, counter, strings.NewReader(`# TYPE requests_total counter
+requests_total 5
+`))
+}
+
+func TestCollectAndCompareFailure(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	counter.Add(5)
+
+	m := &mockReporter{}
+	CollectAndCompare(m, counter, strings.NewReader(`# HELP requests_total Total number of requests.
+# TYPE requests_total counter
+requests_total 3
+`))
+
+	if !m.failed() {
+		t.Fatal("expected CollectAndCompare to fail for a mismatching value")
+	}
+}
+
+func TestCollectAndFormat(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	counter.Add(2)
+
+	out, err := CollectAndFormat(counter, expfmt.NewFormat(expfmt.TypeTextPlain))
+	if err != nil {
+		t.Fatalf("CollectAndFormat failed: %v", err)
+	}
+
+	if !strings.Contains(string(out), "requests_total 2") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
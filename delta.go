@@ -0,0 +1,62 @@
+package promtest
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// AssertAbsent fails if any metric matching the given label subset is
+// present. This is useful to assert that a code path did not touch a counter
+// or gauge at all.
+//
+// Example usage:
+//   promtest.AssertAbsent(t, requestMethodMetric, "method=DELETE")
+func AssertAbsent(t Reporter, metric prometheus.Collector, labels ...string) {
+	t.Helper()
+	filtered := filterMetrics(t, metric, labels)
+
+	if len(filtered) > 0 {
+		t.Errorf("Expected no metric with labels=%q to be present but found %d", labels, len(filtered))
+	}
+}
+
+// AssertDelta snapshots the current aggregated value of a counter or gauge
+// metric matching the given label subset and returns a closure that, once
+// called, asserts that the value has increased by exactly delta since the
+// snapshot was taken. This makes it idiomatic to wrap a call site with
+// defer:
+//
+//   defer promtest.AssertDelta(t, 1, requestsMetric, "method=GET")()
+func AssertDelta(t Reporter, delta float64, metric prometheus.Collector, labels ...string) func() {
+	t.Helper()
+	before := aggregateValue(t, filterMetrics(t, metric, labels))
+
+	return func() {
+		t.Helper()
+		after := aggregateValue(t, filterMetrics(t, metric, labels))
+		actualDelta := after - before
+
+		if actualDelta != delta {
+			t.Errorf("Expected metric with labels=%q to change by %v but it changed by %v", labels, delta, actualDelta)
+		}
+	}
+}
+
+// aggregateValue sums the value of the given counter or gauge metrics.
+func aggregateValue(t Reporter, metrics []*dto.Metric) float64 {
+	t.Helper()
+
+	var value float64
+	for _, m := range metrics {
+		switch {
+		case m.Counter != nil:
+			value += m.GetCounter().GetValue()
+		case m.Gauge != nil:
+			value += m.GetGauge().GetValue()
+		default:
+			t.Fatal("neither a counter nor a gauge")
+		}
+	}
+
+	return value
+}
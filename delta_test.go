@@ -0,0 +1,50 @@
+package promtest
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestAssertAbsent(t *testing.T) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	}, []string{"method"})
+	counter.WithLabelValues("GET").Add(1)
+
+	AssertAbsent(t, counter, "method=DELETE")
+
+	m := &mockReporter{}
+	AssertAbsent(m, counter, "method=GET")
+	if !m.failed() {
+		t.Fatal("expected AssertAbsent to fail when the metric is present")
+	}
+}
+
+func TestAssertDelta(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+
+	done := AssertDelta(t, 2, counter)
+	counter.Add(2)
+	done()
+}
+
+func TestAssertDeltaFailure(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+
+	m := &mockReporter{}
+	done := AssertDelta(m, 2, counter)
+	counter.Add(3)
+	done()
+
+	if !m.failed() {
+		t.Fatal("expected AssertDelta to fail when the observed delta does not match")
+	}
+}
@@ -0,0 +1,85 @@
+package promtest
+
+import "strings"
+
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffRemove
+	diffAdd
+)
+
+type diffOp struct {
+	kind diffKind
+	line string
+}
+
+// diffLines returns a human readable, unified-diff-style representation of
+// the differences between want and got. Lines are compared as-is; no attempt
+// is made to understand the text's semantics.
+func diffLines(want, got string) string {
+	ops := lcsDiff(strings.Split(want, "\n"), strings.Split(got, "\n"))
+
+	var b strings.Builder
+	for _, op := range ops {
+		switch op.kind {
+		case diffRemove:
+			b.WriteString("- " + op.line + "\n")
+		case diffAdd:
+			b.WriteString("+ " + op.line + "\n")
+		default:
+			b.WriteString("  " + op.line + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// lcsDiff computes a minimal line-based diff between a and b, based on their
+// longest common subsequence of lines.
+func lcsDiff(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{diffEqual, a[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			ops = append(ops, diffOp{diffRemove, a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{diffAdd, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{diffRemove, a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{diffAdd, b[j]})
+	}
+
+	return ops
+}
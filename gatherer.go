@@ -0,0 +1,93 @@
+package promtest
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil/promlint"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// CollectFromGatherer extracts all metrics belonging to the metric family
+// with the given name from a prometheus.Gatherer. Consider using
+// promtest.AssertGathererEquals(…) instead of this function.
+func CollectFromGatherer(t Reporter, g prometheus.Gatherer, name string) []*dto.Metric {
+	t.Helper()
+
+	families, err := g.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	for _, mf := range families {
+		if mf.GetName() == name {
+			return mf.GetMetric()
+		}
+	}
+
+	return nil
+}
+
+// AssertGathererEquals checks if the value of a given counter or gauge metric
+// family, gathered from a prometheus.Gatherer such as
+// prometheus.DefaultGatherer, is equal to an expected value. Like
+// promtest.AssertEquals(…), this function aggregates the value from all
+// label combinations that match the given label set.
+//
+// Example usage:
+//   promtest.AssertGathererEquals(t, 5, prometheus.DefaultGatherer, "requests_total", "method=GET")
+func AssertGathererEquals(t Reporter, expected float64, g prometheus.Gatherer, name string, labels ...string) {
+	t.Helper()
+
+	var filtered []*dto.Metric
+	for _, m := range CollectFromGatherer(t, g, name) {
+		if matches(t, m, labels) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	actualValue := aggregateValue(t, filtered)
+	if expected != actualValue {
+		t.Errorf("Expected metric %q with labels=%q to have a value of %v but we got %v", name, labels, expected, actualValue)
+	}
+}
+
+// AssertGathererAbsent fails if a prometheus.Gatherer such as
+// prometheus.DefaultGatherer exposes any metric of the given family name that
+// matches the given label set.
+//
+// Example usage:
+//   promtest.AssertGathererAbsent(t, prometheus.DefaultGatherer, "requests_total", "method=DELETE")
+func AssertGathererAbsent(t Reporter, g prometheus.Gatherer, name string, labels ...string) {
+	t.Helper()
+
+	for _, m := range CollectFromGatherer(t, g, name) {
+		if matches(t, m, labels) {
+			t.Errorf("Expected metric %q with labels=%q to be absent but it was found", name, labels)
+			return
+		}
+	}
+}
+
+// Lint runs promlint over all metric families exposed by a prometheus.Gatherer
+// and fails the test if any lint problem is found, e.g. non-idiomatic metric
+// names or missing units. This is typically run against
+// prometheus.DefaultGatherer as part of a CI test suite.
+//
+// Example usage:
+//   promtest.Lint(t, prometheus.DefaultGatherer)
+func Lint(t Reporter, g prometheus.Gatherer) {
+	t.Helper()
+
+	families, err := g.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	problems, err := promlint.NewWithMetricFamilies(families).Lint()
+	if err != nil {
+		t.Fatalf("Failed to lint metrics: %v", err)
+	}
+
+	for _, p := range problems {
+		t.Errorf("Metric %q: %s", p.Metric, p.Text)
+	}
+}
@@ -0,0 +1,52 @@
+package promtest
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestAssertGathererEqualsAndAbsent(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	}, []string{"method"})
+	counter.WithLabelValues("GET").Add(4)
+	reg.MustRegister(counter)
+
+	AssertGathererEquals(t, 4, reg, "requests_total", "method=GET")
+	AssertGathererAbsent(t, reg, "requests_total", "method=DELETE")
+
+	m := &mockReporter{}
+	AssertGathererAbsent(m, reg, "requests_total", "method=GET")
+	if !m.failed() {
+		t.Fatal("expected AssertGathererAbsent to fail when the metric is present")
+	}
+}
+
+func TestLint(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	reg.MustRegister(counter)
+
+	Lint(t, reg)
+}
+
+func TestLintFailure(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests", // missing the conventional "_total" suffix for a counter
+		Help: "Total number of requests.",
+	})
+	reg.MustRegister(counter)
+
+	m := &mockReporter{}
+	Lint(m, reg)
+	if !m.failed() {
+		t.Fatal("expected Lint to flag the counter missing its _total suffix")
+	}
+}
@@ -0,0 +1,144 @@
+package promtest
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// AssertHistogramSampleCount checks if the sample count of a given histogram
+// metric is equal to an expected value. Other than promtest.GetMetric(…), this
+// function aggregates the value from all label combinations that match the
+// given label set.
+//
+// Example usage:
+//   promtest.AssertHistogramSampleCount(t, 5, requestDurationsMetric, "method=GET")
+func AssertHistogramSampleCount(t Reporter, expected int, metric prometheus.Collector, labels ...string) {
+	t.Helper()
+	filtered := filterMetrics(t, metric, labels)
+
+	var actualValue int
+	for _, m := range filtered {
+		if m.Histogram == nil {
+			t.Fatal("metric is not a histogram")
+		}
+
+		actualValue += int(m.Histogram.GetSampleCount())
+	}
+
+	if expected != actualValue {
+		t.Errorf("Expected metric with labels=%q to have a sample count of %v but we got %v", labels, expected, actualValue)
+	}
+}
+
+// AssertHistogramSum checks if the sample sum of a given histogram metric is
+// equal to an expected value. Other than promtest.GetMetric(…), this function
+// aggregates the value from all label combinations that match the given label
+// set.
+//
+// Example usage:
+//   promtest.AssertHistogramSum(t, 12.3, requestDurationsMetric, "method=GET")
+func AssertHistogramSum(t Reporter, expected float64, metric prometheus.Collector, labels ...string) {
+	t.Helper()
+	filtered := filterMetrics(t, metric, labels)
+
+	var actualValue float64
+	for _, m := range filtered {
+		if m.Histogram == nil {
+			t.Fatal("metric is not a histogram")
+		}
+
+		actualValue += m.Histogram.GetSampleSum()
+	}
+
+	if expected != actualValue {
+		t.Errorf("Expected metric with labels=%q to have a sample sum of %v but we got %v", labels, expected, actualValue)
+	}
+}
+
+// AssertBucketCount checks if the cumulative count of the histogram bucket
+// with the given upper bound (le) is equal to an expected value. Other than
+// promtest.GetMetric(…), this function aggregates the value from all label
+// combinations that match the given label set.
+//
+// Example usage:
+//   promtest.AssertBucketCount(t, 5, requestDurationsMetric, 0.5, "method=GET")
+func AssertBucketCount(t Reporter, expected uint64, metric prometheus.Collector, le float64, labels ...string) {
+	t.Helper()
+	filtered := filterMetrics(t, metric, labels)
+
+	var actualValue uint64
+	var bucketFound bool
+	for _, m := range filtered {
+		if m.Histogram == nil {
+			t.Fatal("metric is not a histogram")
+		}
+
+		for _, b := range m.Histogram.GetBucket() {
+			if b.GetUpperBound() == le {
+				bucketFound = true
+				actualValue += b.GetCumulativeCount()
+			}
+		}
+	}
+
+	if !bucketFound {
+		t.Errorf("Expected metric with labels=%q to have a bucket with le=%v but none was found", labels, le)
+		return
+	}
+
+	if expected != actualValue {
+		t.Errorf("Expected metric with labels=%q to have a bucket count of %v for le=%v but we got %v", labels, expected, le, actualValue)
+	}
+}
+
+// AssertSummaryQuantile checks if the value of a given quantile of a summary
+// metric is equal to an expected value. Other than promtest.GetMetric(…), this
+// function aggregates the value from all label combinations that match the
+// given label set.
+//
+// Example usage:
+//   promtest.AssertSummaryQuantile(t, 0.99, 42.0, requestDurationsMetric, "method=GET")
+func AssertSummaryQuantile(t Reporter, quantile, expected float64, metric prometheus.Collector, labels ...string) {
+	t.Helper()
+	filtered := filterMetrics(t, metric, labels)
+
+	var actualValue float64
+	var quantileFound bool
+	for _, m := range filtered {
+		if m.Summary == nil {
+			t.Fatal("metric is not a summary")
+		}
+
+		for _, q := range m.Summary.GetQuantile() {
+			if q.GetQuantile() == quantile {
+				quantileFound = true
+				actualValue += q.GetValue()
+			}
+		}
+	}
+
+	if !quantileFound {
+		t.Errorf("Expected metric with labels=%q to have a quantile=%v but none was found", labels, quantile)
+		return
+	}
+
+	if expected != actualValue {
+		t.Errorf("Expected metric with labels=%q to have a value of %v for quantile=%v but we got %v", labels, expected, quantile, actualValue)
+	}
+}
+
+// filterMetrics collects all values from the given prometheus metric and
+// returns only the ones that match the given label set.
+func filterMetrics(t Reporter, metric prometheus.Collector, labels []string) []*dto.Metric {
+	t.Helper()
+	allMetrics := CollectMetrics(t, metric)
+
+	var filtered []*dto.Metric
+	for _, m := range allMetrics {
+		if matches(t, m, labels) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
+}
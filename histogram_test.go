@@ -0,0 +1,68 @@
+package promtest
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestAssertHistogramSampleCountAndSum(t *testing.T) {
+	hist := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "request_duration_seconds",
+		Help:    "Request duration in seconds.",
+		Buckets: []float64{0.1, 0.5, 1},
+	}, []string{"method"})
+
+	hist.WithLabelValues("GET").Observe(0.05)
+	hist.WithLabelValues("GET").Observe(0.2)
+	hist.WithLabelValues("POST").Observe(0.9)
+
+	AssertHistogramSampleCount(t, 2, hist, "method=GET")
+	AssertHistogramSampleCount(t, 3, hist)
+	AssertHistogramSum(t, 0.25, hist, "method=GET")
+	AssertBucketCount(t, 1, hist, 0.1, "method=GET")
+	AssertBucketCount(t, 2, hist, 0.5, "method=GET")
+}
+
+func TestAssertHistogramSampleCountFailure(t *testing.T) {
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "request_duration_seconds",
+		Help: "Request duration in seconds.",
+	})
+	hist.Observe(1)
+
+	m := &mockReporter{}
+	AssertHistogramSampleCount(m, 5, hist)
+	if !m.failed() {
+		t.Fatal("expected AssertHistogramSampleCount to fail for a mismatching count")
+	}
+}
+
+func TestAssertSummaryQuantile(t *testing.T) {
+	summary := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Name:       "request_duration_seconds",
+		Help:       "Request duration in seconds.",
+		Objectives: map[float64]float64{0.5: 0.05},
+	}, []string{"method"})
+
+	summary.WithLabelValues("GET").Observe(1)
+	summary.WithLabelValues("GET").Observe(2)
+	summary.WithLabelValues("GET").Observe(3)
+
+	AssertSummaryQuantile(t, 0.5, 2, summary, "method=GET")
+}
+
+func TestAssertSummaryQuantileMissing(t *testing.T) {
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{
+		Name:       "request_duration_seconds",
+		Help:       "Request duration in seconds.",
+		Objectives: map[float64]float64{0.5: 0.05},
+	})
+	summary.Observe(1)
+
+	m := &mockReporter{}
+	AssertSummaryQuantile(m, 0.99, 1, summary)
+	if !m.failed() {
+		t.Fatal("expected AssertSummaryQuantile to fail for a quantile that was never observed")
+	}
+}
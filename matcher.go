@@ -0,0 +1,221 @@
+package promtest
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// MatchType describes how a Matcher compares a label value.
+type MatchType int
+
+const (
+	// MatchEqual matches a label whose value equals Matcher.Value.
+	MatchEqual MatchType = iota
+	// MatchNotEqual matches a label whose value does not equal Matcher.Value.
+	MatchNotEqual
+	// MatchRegexp matches a label whose value matches the regular expression
+	// in Matcher.Value.
+	MatchRegexp
+	// MatchNotRegexp matches a label whose value does not match the regular
+	// expression in Matcher.Value.
+	MatchNotRegexp
+)
+
+// Matcher is a Prometheus-style label matcher. It is the programmatic
+// equivalent of the "key=value" string DSL accepted by functions such as
+// promtest.AssertEquals(…), for callers that would rather build matchers in
+// code than parse them from strings.
+type Matcher struct {
+	Name  string
+	Value string
+	Type  MatchType
+
+	re *regexp.Regexp
+}
+
+// match reports whether the given metric satisfies matcher. MatchEqual and
+// MatchRegexp require the label to be present; for the negated types a
+// missing label is compared as the value "".
+func (matcher Matcher) match(m *dto.Metric) bool {
+	value, ok := labelValue(m, matcher.Name)
+
+	re := matcher.re
+	if re == nil && (matcher.Type == MatchRegexp || matcher.Type == MatchNotRegexp) {
+		// Matchers built in code have no compiled expression yet; an invalid
+		// expression matches nothing.
+		var err error
+		if re, err = regexp.Compile("^(?:" + matcher.Value + ")$"); err != nil {
+			return false
+		}
+	}
+
+	switch matcher.Type {
+	case MatchEqual:
+		return ok && value == matcher.Value
+	case MatchNotEqual:
+		// A missing label implicitly has the value "", same as Prometheus'
+		// own matcher semantics, so it must still be compared rather than
+		// treated as an automatic match.
+		return value != matcher.Value
+	case MatchRegexp:
+		return ok && re.MatchString(value)
+	case MatchNotRegexp:
+		return !re.MatchString(value)
+	default:
+		return false
+	}
+}
+
+func labelValue(m *dto.Metric, name string) (string, bool) {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue(), true
+		}
+	}
+
+	return "", false
+}
+
+// parseMatchers parses the "key=value" style label expressions of the string
+// DSL used throughout promtest into Matchers. The following operators are
+// supported, mirroring Prometheus' own label matcher syntax:
+//
+//   key=value   MatchEqual
+//   key!=value  MatchNotEqual
+//   key=~regex  MatchRegexp
+//   key!~regex  MatchNotRegexp
+func parseMatchers(t Reporter, expressions []string) []Matcher {
+	t.Helper()
+
+	matchers := make([]Matcher, 0, len(expressions))
+	for _, expr := range expressions {
+		m, ok := parseMatcher(t, expr)
+		if ok {
+			matchers = append(matchers, m)
+		}
+	}
+
+	return matchers
+}
+
+func parseMatcher(t Reporter, expr string) (Matcher, bool) {
+	t.Helper()
+
+	operators := []struct {
+		sep string
+		typ MatchType
+	}{
+		{"!=", MatchNotEqual},
+		{"=~", MatchRegexp},
+		{"!~", MatchNotRegexp},
+		{"=", MatchEqual},
+	}
+
+	for _, op := range operators {
+		idx := strings.Index(expr, op.sep)
+		if idx < 0 {
+			continue
+		}
+
+		m := Matcher{Name: expr[:idx], Value: expr[idx+len(op.sep):], Type: op.typ}
+		if m.Type == MatchRegexp || m.Type == MatchNotRegexp {
+			re, err := regexp.Compile("^(?:" + m.Value + ")$")
+			if err != nil {
+				t.Errorf("invalid regular expression %q: %v", m.Value, err)
+				return Matcher{}, false
+			}
+			m.re = re
+		}
+
+		return m, true
+	}
+
+	t.Error("metrics labels should have two parts, e.g. key=value")
+	return Matcher{}, false
+}
+
+// matchesAll reports whether m satisfies every one of the given matchers.
+func matchesAll(m *dto.Metric, matchers []Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher.match(m) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesExactly reports whether m satisfies every one of the given matchers
+// and has no labels beyond the ones they describe.
+func matchesExactly(m *dto.Metric, matchers []Matcher) bool {
+	if !matchesAll(m, matchers) {
+		return false
+	}
+
+	for _, l := range m.GetLabel() {
+		described := false
+		for _, matcher := range matchers {
+			if matcher.Name == l.GetName() {
+				described = true
+				break
+			}
+		}
+		if !described {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FindMetrics extracts all metrics from a prometheus collector that satisfy
+// every one of the given matchers. It is the plural of promtest.GetMetric(…),
+// for callers that need to make assertions across more than one matching
+// series.
+//
+// Example usage:
+//   metrics := promtest.FindMetrics(t, requestDurationsMetric, promtest.Matcher{Name: "method", Value: "GET"})
+func FindMetrics(t Reporter, metric prometheus.Collector, matchers ...Matcher) []*dto.Metric {
+	t.Helper()
+	all := CollectMetrics(t, metric)
+
+	var found []*dto.Metric
+	for _, m := range all {
+		if matchesAll(m, matchers) {
+			found = append(found, m)
+		}
+	}
+
+	return found
+}
+
+// AssertEqualsExact behaves like promtest.AssertEquals(…) but additionally
+// fails if a matching metric carries labels beyond the ones given in labels.
+//
+// Example usage:
+//   promtest.AssertEqualsExact(t, 5, requestMethodMetric, "method=GET")
+func AssertEqualsExact(t Reporter, expected float64, metric prometheus.Collector, labels ...string) {
+	t.Helper()
+	matchers := parseMatchers(t, labels)
+	all := CollectMetrics(t, metric)
+
+	var filtered []*dto.Metric
+	for _, m := range all {
+		if !matchesAll(m, matchers) {
+			continue
+		}
+		if !matchesExactly(m, matchers) {
+			t.Errorf("Expected metric with exact labels=%q but found a matching metric with labels %q", labels, m.GetLabel())
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+
+	actualValue := aggregateValue(t, filtered)
+	if expected != actualValue {
+		t.Errorf("Expected metric with exact labels=%q to have a value of %v but we got %v", labels, expected, actualValue)
+	}
+}
@@ -0,0 +1,80 @@
+package promtest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMatchesRegexAndNegation(t *testing.T) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	}, []string{"method", "code"})
+
+	counter.WithLabelValues("GET", "200").Add(3)
+	counter.WithLabelValues("GET", "500").Add(1)
+	counter.WithLabelValues("POST", "200").Add(2)
+
+	AssertEquals(t, 4, counter, "method=GET")
+	AssertEquals(t, 3, counter, "method=GET", "code!=500")
+	AssertEquals(t, 6, counter, `code=~200|500`)
+	AssertEquals(t, 1, counter, `code!~200`)
+}
+
+func TestAssertEqualsExact(t *testing.T) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	}, []string{"method", "code"})
+
+	counter.WithLabelValues("GET", "200").Add(3)
+
+	AssertEqualsExact(t, 3, counter, "method=GET", "code=200")
+
+	m := &mockReporter{}
+	AssertEqualsExact(m, 3, counter, "method=GET")
+	if !m.failed() {
+		t.Fatal("expected AssertEqualsExact to fail when labels don't cover the full label set")
+	}
+}
+
+// TestMatchesMissingLabel makes sure a missing label is treated as having
+// the implicit value "", matching Prometheus' own matcher semantics, rather
+// than as an automatic match for negated matchers.
+func TestMatchesMissingLabel(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	counter.Add(1)
+
+	if found := FindMetrics(t, counter, Matcher{Name: "env", Type: MatchNotEqual, Value: ""}); len(found) != 0 {
+		t.Fatalf("expected env!= to not match a metric without an env label, got %d", len(found))
+	}
+
+	if found := FindMetrics(t, counter, Matcher{Name: "env", Type: MatchNotEqual, Value: "prod"}); len(found) != 1 {
+		t.Fatalf("expected env!=prod to match a metric without an env label, got %d", len(found))
+	}
+
+	notRegexp := Matcher{Name: "env", Type: MatchNotRegexp, Value: ".*", re: regexp.MustCompile("^(?:.*)$")}
+	if found := FindMetrics(t, counter, notRegexp); len(found) != 0 {
+		t.Fatalf("expected env!~.* to not match a metric without an env label, got %d", len(found))
+	}
+}
+
+func TestFindMetrics(t *testing.T) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	}, []string{"method"})
+
+	counter.WithLabelValues("GET").Add(1)
+	counter.WithLabelValues("POST").Add(1)
+
+	found := FindMetrics(t, counter, Matcher{Name: "method", Type: MatchEqual, Value: "GET"})
+	if len(found) != 1 {
+		t.Fatalf("expected 1 matching metric, got %d", len(found))
+	}
+}
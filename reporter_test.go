@@ -0,0 +1,38 @@
+package promtest
+
+import "fmt"
+
+// mockReporter is a minimal Reporter used to test promtest's own assertions
+// without failing the enclosing *testing.T when an assertion under test is
+// expected to fail.
+type mockReporter struct {
+	errors []string
+	fatal  bool
+}
+
+func (m *mockReporter) Log(args ...interface{})                 {}
+func (m *mockReporter) Logf(format string, args ...interface{}) {}
+func (m *mockReporter) Helper()                                 {}
+func (m *mockReporter) FailNow()                                {}
+
+func (m *mockReporter) Error(args ...interface{}) {
+	m.errors = append(m.errors, fmt.Sprint(args...))
+}
+
+func (m *mockReporter) Errorf(format string, args ...interface{}) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func (m *mockReporter) Fatal(args ...interface{}) {
+	m.fatal = true
+	m.errors = append(m.errors, fmt.Sprint(args...))
+}
+
+func (m *mockReporter) Fatalf(format string, args ...interface{}) {
+	m.fatal = true
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func (m *mockReporter) failed() bool {
+	return len(m.errors) > 0
+}
@@ -0,0 +1,83 @@
+package promtest
+
+import (
+	"io"
+	"net/http"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// acceptHeader is the Accept header sent by ScrapeAndCompare to let the
+// server negotiate the best exposition format it supports, mirroring what a
+// real Prometheus server sends when scraping a target.
+const acceptHeader = `text/plain;version=0.0.4;q=0.5,*/*;q=0.1`
+
+// ScrapeAndCompare performs an HTTP GET against url, typically the address of
+// a promhttp.Handler(), and compares the text exposition of the metric
+// families named in metricNames (or all families if none are given) against
+// the expected exposition format read from expected. If the two differ, a
+// diff is reported via t.Errorf. Non-200 responses are reported via
+// t.Fatalf.
+//
+// Example usage:
+//   server := httptest.NewServer(promhttp.Handler())
+//   defer server.Close()
+//   promtest.ScrapeAndCompare(t, server.URL, strings.NewReader(`
+//   # HELP requests_total Total number of requests.
+//   # TYPE requests_total counter
+//   requests_total{method="GET"} 5
+//   `))
+func ScrapeAndCompare(t Reporter, url string, expected io.Reader, metricNames ...string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("Failed to build scrape request for %s: %v", url, err)
+	}
+	req.Header.Set("Accept", acceptHeader)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to scrape %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Scraping %s returned unexpected status %s", url, resp.Status)
+	}
+
+	got, err := decodeMetricFamilies(resp.Body, expfmt.ResponseFormat(resp.Header))
+	if err != nil {
+		t.Fatalf("Failed to decode metrics scraped from %s: %v", url, err)
+	}
+
+	want := parseExpectedMetricFamilies(t, expected)
+
+	got = filterMetricFamiliesByName(got, metricNames)
+	want = filterMetricFamiliesByName(want, metricNames)
+
+	compareMetricFamilies(t, "Metrics scraped from "+url, got, want)
+}
+
+// decodeMetricFamilies reads and decodes all metric families from r, encoded
+// in the given exposition format.
+func decodeMetricFamilies(r io.Reader, format expfmt.Format) ([]*dto.MetricFamily, error) {
+	dec := expfmt.NewDecoder(r, format)
+
+	var families []*dto.MetricFamily
+	for {
+		var mf dto.MetricFamily
+		err := dec.Decode(&mf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		families = append(families, &mf)
+	}
+
+	return families, nil
+}
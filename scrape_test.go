@@ -0,0 +1,62 @@
+package promtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func TestScrapeAndCompare(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	counter.Add(7)
+	reg.MustRegister(counter)
+
+	server := httptest.NewServer(promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	ScrapeAndCompare(t, server.URL, strings.NewReader(`# HELP requests_total Total number of requests.
+# TYPE requests_total counter
+requests_total 7
+`))
+}
+
+// TestScrapeAndCompareWithoutHelp mirrors TestCollectAndCompareWithoutHelp:
+// a golden fixture without "# HELP" must still compare equal.
+func TestScrapeAndCompareWithoutHelp(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "requests_total",
+		Help: "Total number of requests.",
+	})
+	counter.Add(7)
+	reg.MustRegister(counter)
+
+	server := httptest.NewServer(promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	ScrapeAndCompare(t, server.URL, strings.NewReader(`# TYPE requests_total counter
+requests_total 7
+`))
+}
+
+func TestScrapeAndCompareNon200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	m := &mockReporter{}
+	ScrapeAndCompare(m, server.URL, strings.NewReader(""))
+
+	if !m.fatal {
+		t.Fatal("expected ScrapeAndCompare to fail fatally for a non-200 response")
+	}
+}